@@ -0,0 +1,667 @@
+package llmsearch
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+	bleveQuery "github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/lang/ru"
+	"github.com/blevesearch/bleve/v2/mapping"
+
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+// bleveIndexBatchSize controls how many documents are buffered per Bleve
+// batch write so re-indexing large tool sets doesn't hold everything in
+// memory or rebuild the index from scratch.
+const bleveIndexBatchSize = 200
+
+// bleveToolDoc is the document shape indexed into Bleve for each tool.
+// InputSchemaText is a flattened string of property names and
+// descriptions so schema fields are searchable without modeling the
+// full JSON schema as Bleve fields.
+type bleveToolDoc struct {
+	Name            string `json:"name"`
+	Category        string `json:"category"`
+	Description     string `json:"description"`
+	InputSchemaText string `json:"input_schema_text"`
+}
+
+// BleveSearchStore is a persistent, BM25-scored search store backed by
+// Bleve. Unlike MockSearchStore's in-memory linear scan, the index
+// survives process restarts and scales to large tool catalogs. Bleve's
+// scorch storage gives each index.Search call a consistent
+// point-in-time view, and the tools map is replaced wholesale on every
+// write rather than mutated in place. A reader from Reader fixes only
+// the tools map; its searches run against the live index (see Reader).
+type BleveSearchStore struct {
+	mu           sync.RWMutex
+	index        bleve.Index
+	indexPath    string
+	analyzerName string
+	tools        map[string]*tools.Tool
+	logger       *slog.Logger
+}
+
+// NewBleveSearchStore opens (or creates) a persistent Bleve index at
+// indexPath using analyzerName for the Name/Category/Description/schema
+// fields. analyzerName is looked up in the per-field analyzer registry
+// (e.g. "en", "ru"); an empty name selects the English analyzer. It is
+// only used when a new index is created: an existing index keeps the
+// mapping it was created with.
+func NewBleveSearchStore(indexPath string, analyzerName string, logger *slog.Logger) (*BleveSearchStore, error) {
+	index, err := bleve.Open(indexPath)
+
+	var toolMap map[string]*tools.Tool
+
+	if err == nil {
+		logger.Info("Opened existing Bleve index", "path", indexPath)
+
+		toolMap, err = loadToolsFromIndex(index, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebuild tool map from reopened index: %w", err)
+		}
+	} else {
+		logger.Info("Creating new Bleve index", "path", indexPath, "analyzer", analyzerName)
+
+		indexMapping, mapErr := buildBleveIndexMapping(analyzerName)
+		if mapErr != nil {
+			return nil, fmt.Errorf("failed to build index mapping: %w", mapErr)
+		}
+
+		index, err = bleve.New(indexPath, indexMapping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Bleve index: %w", err)
+		}
+
+		toolMap = make(map[string]*tools.Tool)
+	}
+
+	return &BleveSearchStore{
+		index:        index,
+		indexPath:    indexPath,
+		analyzerName: analyzerName,
+		tools:        toolMap,
+		logger:       logger,
+	}, nil
+}
+
+// loadToolsFromIndex rebuilds the id-to-tool map from a reopened Bleve
+// index's stored fields, paging through every document via MatchAll.
+// Only Name/Category/Description are stored fields (see
+// buildBleveIndexMapping), so InputSchema comes back empty on reopened
+// tools until the next BuildFromTools/Batch repopulates it.
+func loadToolsFromIndex(index bleve.Index, logger *slog.Logger) (map[string]*tools.Tool, error) {
+	toolMap := make(map[string]*tools.Tool)
+
+	const pageSize = 1000
+	from := 0
+
+	for {
+		req := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), pageSize, from, false)
+		req.Fields = []string{"category", "description"}
+
+		result, err := index.Search(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list documents from index: %w", err)
+		}
+
+		for _, hit := range result.Hits {
+			tool := &tools.Tool{Name: hit.ID}
+			if category, ok := hit.Fields["category"].(string); ok {
+				tool.Category = category
+			}
+			if description, ok := hit.Fields["description"].(string); ok {
+				tool.Description = description
+			}
+			toolMap[tool.Name] = tool
+		}
+
+		from += len(result.Hits)
+		if len(result.Hits) == 0 || uint64(from) >= result.Total {
+			break
+		}
+	}
+
+	logger.Info("Rebuilt tool map from reopened Bleve index", "tool_count", len(toolMap))
+
+	return toolMap, nil
+}
+
+// buildBleveIndexMapping registers the language analyzer against the
+// Name/Category/Description/schema fields. Field weights are not part
+// of the mapping; Search applies them as per-field query boosts.
+func buildBleveIndexMapping(analyzerName string) (mapping.IndexMapping, error) {
+	indexMapping := bleve.NewIndexMapping()
+
+	switch analyzerName {
+	case "en", "":
+		analyzerName = en.AnalyzerName
+	case "ru":
+		analyzerName = ru.AnalyzerName
+	default:
+		return nil, fmt.Errorf("unknown analyzer: %s (available: en, ru)", analyzerName)
+	}
+
+	nameField := bleve.NewTextFieldMapping()
+	nameField.Analyzer = analyzerName
+	nameField.Store = true
+
+	categoryField := bleve.NewTextFieldMapping()
+	categoryField.Analyzer = analyzerName
+	categoryField.Store = true
+
+	descriptionField := bleve.NewTextFieldMapping()
+	descriptionField.Analyzer = analyzerName
+	descriptionField.Store = true
+
+	schemaField := bleve.NewTextFieldMapping()
+	schemaField.Analyzer = analyzerName
+
+	toolMapping := bleve.NewDocumentMapping()
+	toolMapping.AddFieldMappingsAt("name", nameField)
+	toolMapping.AddFieldMappingsAt("category", categoryField)
+	toolMapping.AddFieldMappingsAt("description", descriptionField)
+	toolMapping.AddFieldMappingsAt("input_schema_text", schemaField)
+
+	indexMapping.DefaultMapping = toolMapping
+	indexMapping.DefaultAnalyzer = analyzerName
+
+	return indexMapping, nil
+}
+
+// BuildFromTools indexes allTools in batches of bleveIndexBatchSize so
+// large tool sets don't require holding the whole index rebuild in one
+// batch, then deletes any previously-indexed tool absent from allTools
+// so a shrinking tool set doesn't leave stale documents occupying ranked
+// slots in the underlying Bleve index.
+func (s *BleveSearchStore) BuildFromTools(allTools []*tools.Tool) error {
+	s.logger.Info("Building Bleve search index", "tool_count", len(allTools), "path", s.indexPath)
+
+	s.mu.RLock()
+	stale := make(map[string]bool, len(s.tools))
+	for name := range s.tools {
+		stale[name] = true
+	}
+	s.mu.RUnlock()
+
+	toolMap := make(map[string]*tools.Tool, len(allTools))
+	batch := s.index.NewBatch()
+
+	for i, tool := range allTools {
+		toolMap[tool.Name] = tool
+		delete(stale, tool.Name)
+
+		if err := addToolToBatch(batch, tool); err != nil {
+			return err
+		}
+
+		if batch.Size() >= bleveIndexBatchSize {
+			if err := s.index.Batch(batch); err != nil {
+				return fmt.Errorf("failed to write index batch: %w", err)
+			}
+			batch = s.index.NewBatch()
+		}
+
+		if (i+1)%10000 == 0 {
+			s.logger.Info("Indexing tools...", "indexed", i+1)
+		}
+	}
+
+	for name := range stale {
+		batch.Delete(name)
+
+		if batch.Size() >= bleveIndexBatchSize {
+			if err := s.index.Batch(batch); err != nil {
+				return fmt.Errorf("failed to write index batch: %w", err)
+			}
+			batch = s.index.NewBatch()
+		}
+	}
+
+	if batch.Size() > 0 {
+		if err := s.index.Batch(batch); err != nil {
+			return fmt.Errorf("failed to write final index batch: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.tools = toolMap
+	s.mu.Unlock()
+
+	s.logger.Info("Bleve search index built", "tool_count", len(toolMap), "removed", len(stale))
+
+	return nil
+}
+
+// Batch applies adds and removes (matched by tool name) as a single
+// Bleve batch write, leaving any outstanding reader snapshot untouched
+// until it commits.
+func (s *BleveSearchStore) Batch(adds []*tools.Tool, removes []string) error {
+	batch := s.index.NewBatch()
+
+	for _, tool := range adds {
+		if err := addToolToBatch(batch, tool); err != nil {
+			return err
+		}
+	}
+	for _, name := range removes {
+		batch.Delete(name)
+	}
+
+	if err := s.index.Batch(batch); err != nil {
+		return fmt.Errorf("failed to write batch: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := make(map[string]*tools.Tool, len(s.tools)+len(adds))
+	for name, tool := range s.tools {
+		next[name] = tool
+	}
+	for _, name := range removes {
+		delete(next, name)
+	}
+	for _, tool := range adds {
+		next[tool.Name] = tool
+	}
+	s.tools = next
+
+	s.logger.Info("Applied Bleve search store batch", "adds", len(adds), "removes", len(removes), "tool_count", len(next))
+
+	return nil
+}
+
+// addToolToBatch adds a single tool document to an in-progress Bleve batch.
+func addToolToBatch(batch *bleve.Batch, tool *tools.Tool) error {
+	doc := bleveToolDoc{
+		Name:            tool.Name,
+		Category:        tool.Category,
+		Description:     tool.Description,
+		InputSchemaText: flattenInputSchema(tool.InputSchema),
+	}
+
+	if err := batch.Index(tool.Name, doc); err != nil {
+		return fmt.Errorf("failed to add tool %q to batch: %w", tool.Name, err)
+	}
+
+	return nil
+}
+
+// flattenInputSchema joins property names and descriptions from a JSON
+// schema map into a single searchable string.
+func flattenInputSchema(schema any) string {
+	schemaMap, ok := schema.(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	properties, ok := schemaMap["properties"].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	var parts []string
+	for name, propAny := range properties {
+		parts = append(parts, name)
+
+		if prop, ok := propAny.(map[string]any); ok {
+			if desc, ok := prop["description"].(string); ok {
+				parts = append(parts, desc)
+			}
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Reader returns a snapshot of the currently indexed tools. Only the
+// id-to-tool map is fixed at this call: the reader's searches run
+// against the live index, so a Batch or BuildFromTools that commits
+// afterwards changes which documents they return. Hits whose ids are
+// not in the snapshot's map are dropped, while GetToolCount keeps
+// counting the snapshot's tools.
+func (s *BleveSearchStore) Reader() (IndexReader, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &bleveIndexReader{index: s.index, tools: s.tools, logger: s.logger}, nil
+}
+
+// bleveIndexReader answers searches against the live Bleve index and a
+// fixed snapshot of the id-to-tool map captured by
+// BleveSearchStore.Reader.
+type bleveIndexReader struct {
+	index  bleve.Index
+	tools  map[string]*tools.Tool
+	logger *slog.Logger
+}
+
+// Search runs a BM25 query across Name, Category, Description, and the
+// flattened input schema, weighting Name highest, then Category, then
+// Description, and the schema text lowest, and returns the
+// matching tools ordered by score with Matches populated from Bleve's
+// native highlighter and term locations.
+func (r *bleveIndexReader) Search(query string, topK int) ([]*ScoredTool, error) {
+	if len(r.tools) == 0 {
+		return []*ScoredTool{}, nil
+	}
+
+	nameQuery := bleve.NewMatchQuery(query)
+	nameQuery.SetField("name")
+	nameQuery.SetBoost(3.0)
+
+	categoryQuery := bleve.NewMatchQuery(query)
+	categoryQuery.SetField("category")
+	categoryQuery.SetBoost(2.0)
+
+	descriptionQuery := bleve.NewMatchQuery(query)
+	descriptionQuery.SetField("description")
+	descriptionQuery.SetBoost(1.0)
+
+	schemaQuery := bleve.NewMatchQuery(query)
+	schemaQuery.SetField("input_schema_text")
+	schemaQuery.SetBoost(0.5)
+
+	disjunction := bleve.NewDisjunctionQuery(nameQuery, categoryQuery, descriptionQuery, schemaQuery)
+
+	searchRequest := bleve.NewSearchRequestOptions(disjunction, topK, 0, false)
+	searchRequest.Highlight = bleve.NewHighlight()
+	searchRequest.IncludeLocations = true
+
+	searchResult, err := r.index.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("bleve search failed: %w", err)
+	}
+
+	results := make([]*ScoredTool, 0, len(searchResult.Hits))
+	for _, hit := range searchResult.Hits {
+		tool, ok := r.tools[hit.ID]
+		if !ok {
+			continue
+		}
+
+		results = append(results, &ScoredTool{
+			Tool:    tool,
+			Score:   float32(hit.Score),
+			Matches: matchesFromHit(tool, hit),
+		})
+	}
+
+	r.logger.Debug("Bleve search completed", "query", query, "found", len(results))
+
+	return results, nil
+}
+
+// matchesFromHit builds per-field Match metadata from Bleve's native
+// highlighter fragments (for the matched snippet text, falling back to
+// the full stored field when a field has no fragment) and term
+// locations, keyed by field name. hit.Locations' offsets are positions
+// in the full, unmodified field text, not the (possibly windowed)
+// fragment returned as Value, so Start/Length are recomputed by
+// locating each matched term inside the fragment itself; a term the
+// highlighter's window cut out of the fragment is skipped rather than
+// reporting an offset into the wrong string.
+func matchesFromHit(tool *tools.Tool, hit *search.DocumentMatch) map[string][]Match {
+	fieldValues := map[string]string{
+		"name":        tool.Name,
+		"category":    tool.Category,
+		"description": tool.Description,
+	}
+
+	matches := make(map[string][]Match)
+
+	for field, termLocations := range hit.Locations {
+		fieldValue, ok := fieldValues[field]
+		if !ok {
+			continue
+		}
+
+		snippet := fieldValue
+		if fragments, ok := hit.Fragments[field]; ok && len(fragments) > 0 {
+			snippet = fragments[0]
+		}
+		snippetLower := strings.ToLower(snippet)
+
+		for term := range termLocations {
+			termLower := strings.ToLower(term)
+			idx := strings.Index(snippetLower, termLower)
+			if idx < 0 {
+				continue
+			}
+
+			level := MatchLevelPartial
+			if len(term) == len(fieldValue) {
+				level = MatchLevelFull
+			}
+
+			matches[field] = append(matches[field], Match{
+				Value:      snippet,
+				MatchLevel: level,
+				Start:      idx,
+				Length:     len(term),
+			})
+		}
+	}
+
+	return matches
+}
+
+// GetToolCount returns the number of tools in this snapshot.
+func (r *bleveIndexReader) GetToolCount() int {
+	return len(r.tools)
+}
+
+// Close is a no-op: Bleve's own index.Search already operates on a
+// consistent snapshot per call, so there is no separate resource tied
+// to this reader to release.
+func (r *bleveIndexReader) Close() error {
+	return nil
+}
+
+// SearchQuery lowers a compound query tree onto Bleve's native query
+// types and runs it against the index. Bleve has no native min-score
+// query, so any MinScoreQuery in the tree is unwrapped by toBleveQuery.
+// The thresholds of the MinScoreQuery nodes that minScoreClauses
+// returns are enforced here instead, after scoring, by running each
+// one's Inner clause as its own isolated search so the threshold is
+// checked against that clause's own score rather than the combined
+// score of the whole compound query. A MinScoreQuery under an OrQuery
+// or NotQuery is lowered without its threshold.
+func (r *bleveIndexReader) SearchQuery(q query.Query, topK int) ([]*ScoredTool, error) {
+	if len(r.tools) == 0 {
+		return []*ScoredTool{}, nil
+	}
+
+	bq, err := toBleveQuery(q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lower query: %w", err)
+	}
+
+	searchRequest := bleve.NewSearchRequestOptions(bq, topK, 0, false)
+
+	searchResult, err := r.index.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("bleve search query failed: %w", err)
+	}
+
+	gates, err := r.minScoreGates(q)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ScoredTool, 0, len(searchResult.Hits))
+	for _, hit := range searchResult.Hits {
+		if !passesAllGates(gates, hit.ID) {
+			continue
+		}
+		if tool, ok := r.tools[hit.ID]; ok {
+			results = append(results, &ScoredTool{Tool: tool, Score: float32(hit.Score)})
+		}
+	}
+
+	return results, nil
+}
+
+// minScoreClauses returns every MinScoreQuery node in q that
+// unconditionally applies to every result: q itself, and any nested
+// directly inside an AndQuery (recursively), since AND requires every
+// clause to hold. A MinScoreQuery inside an OrQuery or NotQuery does
+// not unconditionally apply, since satisfying a different clause can
+// still match, so those are left out, matching evalQuery's per-clause
+// MinScoreQuery semantics for AND.
+func minScoreClauses(q query.Query) []*query.MinScoreQuery {
+	switch n := q.(type) {
+	case *query.MinScoreQuery:
+		return append([]*query.MinScoreQuery{n}, minScoreClauses(n.Inner)...)
+
+	case *query.AndQuery:
+		var clauses []*query.MinScoreQuery
+		for _, clause := range n.Clauses {
+			clauses = append(clauses, minScoreClauses(clause)...)
+		}
+		return clauses
+
+	default:
+		return nil
+	}
+}
+
+// minScoreGates runs every unconditionally-applying MinScoreQuery
+// clause's Inner query as its own isolated Bleve search and returns, per
+// clause, the set of document ids whose isolated score on that clause
+// meets its threshold. Scoping each threshold to its own sub-search
+// keeps it independent of how high the rest of the compound query
+// scores, matching MockSearchStore.evalQuery's semantics.
+func (r *bleveIndexReader) minScoreGates(q query.Query) ([]map[string]bool, error) {
+	clauses := minScoreClauses(q)
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+
+	docCount, err := r.index.DocCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	gates := make([]map[string]bool, 0, len(clauses))
+
+	for _, clause := range clauses {
+		innerQuery, err := toBleveQuery(clause.Inner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lower MinScoreQuery inner clause: %w", err)
+		}
+
+		req := bleve.NewSearchRequestOptions(innerQuery, int(docCount), 0, false)
+		result, err := r.index.Search(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to score MinScoreQuery inner clause: %w", err)
+		}
+
+		passing := make(map[string]bool, len(result.Hits))
+		for _, hit := range result.Hits {
+			if float32(hit.Score) >= clause.MinScore {
+				passing[hit.ID] = true
+			}
+		}
+		gates = append(gates, passing)
+	}
+
+	return gates, nil
+}
+
+// passesAllGates reports whether id is present in every gate, i.e.
+// whether it meets every unconditionally-applying MinScoreQuery
+// threshold in the original query tree.
+func passesAllGates(gates []map[string]bool, id string) bool {
+	for _, gate := range gates {
+		if !gate[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// toBleveQuery converts a query.Query tree into the equivalent Bleve
+// query.Query, recursing over compound nodes.
+func toBleveQuery(q query.Query) (bleveQuery.Query, error) {
+	switch n := q.(type) {
+	case *query.TermQuery:
+		mq := bleve.NewMatchQuery(n.Term)
+		return mq, nil
+
+	case *query.PhraseQuery:
+		pq := bleve.NewMatchPhraseQuery(n.Phrase)
+		return pq, nil
+
+	case *query.CategoryQuery:
+		mq := bleve.NewMatchQuery(n.Category)
+		mq.SetField("category")
+		return mq, nil
+
+	case *query.AndQuery:
+		clauses, err := toBleveQueries(n.Clauses)
+		if err != nil {
+			return nil, err
+		}
+		return bleve.NewConjunctionQuery(clauses...), nil
+
+	case *query.OrQuery:
+		clauses, err := toBleveQueries(n.Clauses)
+		if err != nil {
+			return nil, err
+		}
+		return bleve.NewDisjunctionQuery(clauses...), nil
+
+	case *query.NotQuery:
+		inner, err := toBleveQuery(n.Clause)
+		if err != nil {
+			return nil, err
+		}
+		// Bleve has no first-class NOT: express it as "match everything
+		// except inner" via a boolean query with only a must-not clause.
+		boolQuery := bleve.NewBooleanQuery()
+		boolQuery.AddMustNot(inner)
+		boolQuery.AddShould(bleve.NewMatchAllQuery())
+		return boolQuery, nil
+
+	case *query.MinScoreQuery:
+		// Bleve has no native min-score query, so just lower the inner
+		// query here; SearchQuery applies the threshold itself via
+		// minScoreGates after scoring.
+		return toBleveQuery(n.Inner)
+
+	case *query.SemanticQuery:
+		return nil, fmt.Errorf("SemanticQuery is not supported by BleveSearchStore; use HybridSearchStore")
+
+	default:
+		return nil, fmt.Errorf("unsupported query node: %T", q)
+	}
+}
+
+func toBleveQueries(clauses []query.Query) ([]bleveQuery.Query, error) {
+	result := make([]bleveQuery.Query, 0, len(clauses))
+	for _, clause := range clauses {
+		bq, err := toBleveQuery(clause)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, bq)
+	}
+	return result, nil
+}
+
+// Close releases the underlying Bleve index.
+func (s *BleveSearchStore) Close() error {
+	return s.index.Close()
+}
+
+var _ IndexReader = (*bleveIndexReader)(nil)
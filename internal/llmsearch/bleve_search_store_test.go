@@ -0,0 +1,145 @@
+package llmsearch
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+// newTestBleveStore opens a fresh Bleve index under t.TempDir, closed
+// automatically when the test ends.
+func newTestBleveStore(t *testing.T) *BleveSearchStore {
+	t.Helper()
+
+	store, err := NewBleveSearchStore(t.TempDir(), "en", slog.Default())
+	if err != nil {
+		t.Fatalf("NewBleveSearchStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestBuildFromToolsDeletesStaleDocuments(t *testing.T) {
+	store := newTestBleveStore(t)
+
+	a := &tools.Tool{Name: "read_file", Category: "filesystem", Description: "Reads a file"}
+	b := &tools.Tool{Name: "write_file", Category: "filesystem", Description: "Writes a file"}
+	if err := store.BuildFromTools([]*tools.Tool{a, b}); err != nil {
+		t.Fatalf("BuildFromTools: %v", err)
+	}
+
+	if err := store.BuildFromTools([]*tools.Tool{a}); err != nil {
+		t.Fatalf("BuildFromTools (shrink): %v", err)
+	}
+
+	reader, err := store.Reader()
+	if err != nil {
+		t.Fatalf("Reader: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.GetToolCount() != 1 {
+		t.Fatalf("expected 1 tool after shrink, got %d", reader.GetToolCount())
+	}
+
+	results, err := reader.Search("file", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	for _, r := range results {
+		if r.Tool.Name == "write_file" {
+			t.Errorf("expected write_file to be deleted from the index, but it was still returned")
+		}
+	}
+}
+
+func TestMatchesFromHitOffsetsIndexIntoValue(t *testing.T) {
+	store := newTestBleveStore(t)
+
+	tool := &tools.Tool{Name: "read_file", Category: "filesystem", Description: "Reads the contents of a file from disk"}
+	if err := store.BuildFromTools([]*tools.Tool{tool}); err != nil {
+		t.Fatalf("BuildFromTools: %v", err)
+	}
+
+	reader, err := store.Reader()
+	if err != nil {
+		t.Fatalf("Reader: %v", err)
+	}
+	defer reader.Close()
+
+	results, err := reader.Search("contents", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	matches, ok := results[0].Matches["description"]
+	if !ok || len(matches) == 0 {
+		t.Fatalf("expected a description match, got %v", results[0].Matches)
+	}
+
+	for _, m := range matches {
+		if m.Start < 0 || m.Start+m.Length > len(m.Value) {
+			t.Fatalf("match offset [%d:%d] out of range for Value %q", m.Start, m.Start+m.Length, m.Value)
+		}
+		if substr := m.Value[m.Start : m.Start+m.Length]; substr == "" {
+			t.Errorf("expected a non-empty matched substring within %q", m.Value)
+		}
+	}
+}
+
+func TestSearchQueryMinScoreScopedToSubClause(t *testing.T) {
+	store := newTestBleveStore(t)
+
+	// "common" appears in every tool's description so it scores low on
+	// its own, while only one tool has "filesystem" as its category.
+	a := &tools.Tool{Name: "read_file", Category: "filesystem", Description: "common operation: reads a file"}
+	b := &tools.Tool{Name: "ping", Category: "network", Description: "common operation: pings a host"}
+	if err := store.BuildFromTools([]*tools.Tool{a, b}); err != nil {
+		t.Fatalf("BuildFromTools: %v", err)
+	}
+
+	reader, err := store.Reader()
+	if err != nil {
+		t.Fatalf("Reader: %v", err)
+	}
+	defer reader.Close()
+
+	// An unreachable min score on the category clause should exclude
+	// every result, even read_file, which matches both the "common" term
+	// and the filesystem category.
+	q := &query.AndQuery{Clauses: []query.Query{
+		&query.TermQuery{Term: "common"},
+		&query.MinScoreQuery{Inner: &query.CategoryQuery{Category: "filesystem"}, MinScore: 1000},
+	}}
+
+	results, err := reader.SearchQuery(q, 10)
+	if err != nil {
+		t.Fatalf("SearchQuery: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results when the category sub-clause can't meet its own min score, got %v", results)
+	}
+}
+
+func TestFlattenInputSchema(t *testing.T) {
+	schema := map[string]any{
+		"properties": map[string]any{
+			"path": map[string]any{"description": "file path to read"},
+		},
+	}
+
+	got := flattenInputSchema(schema)
+	if got != "path file path to read" {
+		t.Errorf("flattenInputSchema = %q, want %q", got, "path file path to read")
+	}
+
+	if got := flattenInputSchema(nil); got != "" {
+		t.Errorf("flattenInputSchema(nil) = %q, want empty", got)
+	}
+}
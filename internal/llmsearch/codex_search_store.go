@@ -4,12 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
 	"github.com/radutopala/onemcp/internal/tools"
 )
 
-// CodexSearchStore uses Codex CLI for semantic search
+// CodexSearchStore uses Codex CLI for semantic search. Writes replace
+// the tools slice and cached schemas wholesale rather than mutating them
+// in place, so a snapshot handed out by Reader stays consistent even if
+// a Batch runs concurrently.
 type CodexSearchStore struct {
+	mu       sync.RWMutex
 	searcher *CodexSearcher
 	tools    []*tools.Tool
 	schemas  []byte // Cached JSON schemas
@@ -29,9 +35,58 @@ func NewCodexSearchStore(searcher *CodexSearcher, logger *slog.Logger) *CodexSea
 func (s *CodexSearchStore) BuildFromTools(allTools []*tools.Tool) error {
 	s.logger.Info("Building Codex search index", "tool_count", len(allTools))
 
-	s.tools = allTools
+	snapshot := make([]*tools.Tool, len(allTools))
+	copy(snapshot, allTools)
 
-	// Build tool metadata with full schemas for Codex
+	schemas, err := marshalToolSchemas(snapshot)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.tools = snapshot
+	s.schemas = schemas
+	s.mu.Unlock()
+
+	s.logger.Info("Codex search index built", "tool_count", len(snapshot), "schema_size_kb", len(schemas)/1024)
+
+	return nil
+}
+
+// Batch applies adds and removes (matched by tool name) and re-marshals
+// the cached schemas, leaving any outstanding reader snapshot untouched.
+func (s *CodexSearchStore) Batch(adds []*tools.Tool, removes []string) error {
+	removeSet := make(map[string]bool, len(removes))
+	for _, name := range removes {
+		removeSet[name] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := make([]*tools.Tool, 0, len(s.tools)+len(adds))
+	for _, tool := range s.tools {
+		if !removeSet[tool.Name] {
+			next = append(next, tool)
+		}
+	}
+	next = append(next, adds...)
+
+	schemas, err := marshalToolSchemas(next)
+	if err != nil {
+		return err
+	}
+
+	s.tools = next
+	s.schemas = schemas
+
+	s.logger.Info("Applied Codex search store batch", "adds", len(adds), "removes", len(removes), "tool_count", len(next))
+
+	return nil
+}
+
+// marshalToolSchemas builds the JSON payload Codex is queried against.
+func marshalToolSchemas(allTools []*tools.Tool) ([]byte, error) {
 	toolSchemas := make([]tools.ToolMetadata, len(allTools))
 	for i, tool := range allTools {
 		metadata := tools.ToolMetadata{
@@ -40,7 +95,6 @@ func (s *CodexSearchStore) BuildFromTools(allTools []*tools.Tool) error {
 			Description: tool.Description,
 		}
 
-		// Include full schema
 		if tool.InputSchema != nil {
 			if schemaMap, ok := tool.InputSchema.(map[string]any); ok {
 				metadata.Parameters = schemaMap
@@ -50,50 +104,105 @@ func (s *CodexSearchStore) BuildFromTools(allTools []*tools.Tool) error {
 		toolSchemas[i] = metadata
 	}
 
-	// Marshal to JSON for Codex
 	schemas, err := json.Marshal(toolSchemas)
 	if err != nil {
-		return fmt.Errorf("failed to marshal tool schemas: %w", err)
+		return nil, fmt.Errorf("failed to marshal tool schemas: %w", err)
 	}
 
-	s.schemas = schemas
+	return schemas, nil
+}
 
-	s.logger.Info("Codex search index built", "tool_count", len(s.tools), "schema_size_kb", len(schemas)/1024)
+// Reader returns a snapshot of the currently cached tools and schemas.
+func (s *CodexSearchStore) Reader() (IndexReader, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &codexIndexReader{
+		searcher: s.searcher,
+		tools:    s.tools,
+		schemas:  s.schemas,
+		logger:   s.logger,
+	}, nil
+}
 
-	return nil
+// codexIndexReader answers searches against a fixed snapshot of tools
+// and schemas captured by CodexSearchStore.Reader.
+type codexIndexReader struct {
+	searcher *CodexSearcher
+	tools    []*tools.Tool
+	schemas  []byte
+	logger   *slog.Logger
 }
 
-// Search uses Codex CLI to find relevant tools
-func (s *CodexSearchStore) Search(query string, topK int) ([]*tools.Tool, error) {
-	if len(s.tools) == 0 {
-		return []*tools.Tool{}, nil
+// Search uses Codex CLI to find relevant tools. Codex returns a ranked
+// list of tool names rather than per-field offsets, so Matches is left
+// nil for each result.
+func (r *codexIndexReader) Search(query string, topK int) ([]*ScoredTool, error) {
+	if len(r.tools) == 0 {
+		return []*ScoredTool{}, nil
 	}
 
 	// Ask Codex to rank tools
-	toolNames, err := s.searcher.SearchTools(query, s.schemas, topK)
+	toolNames, err := r.searcher.SearchTools(query, r.schemas, topK)
 	if err != nil {
 		return nil, fmt.Errorf("codex search failed: %w", err)
 	}
 
-	// Map tool names back to tool objects
-	toolMap := make(map[string]*tools.Tool)
-	for _, tool := range s.tools {
+	results := r.toolsFromNames(toolNames)
+
+	r.logger.Debug("Codex search results", "query", query, "requested", topK, "returned", len(results))
+
+	return results, nil
+}
+
+// SearchQuery lowers a compound query tree to its textual representation
+// and asks Codex to rank tools against it, since Codex has no native
+// query tree of its own.
+func (r *codexIndexReader) SearchQuery(q query.Query, topK int) ([]*ScoredTool, error) {
+	if len(r.tools) == 0 {
+		return []*ScoredTool{}, nil
+	}
+
+	toolNames, err := r.searcher.SearchTools(q.String(), r.schemas, topK)
+	if err != nil {
+		return nil, fmt.Errorf("codex search query failed: %w", err)
+	}
+
+	results := r.toolsFromNames(toolNames)
+
+	r.logger.Debug("Codex search query results", "query", q.String(), "requested", topK, "returned", len(results))
+
+	return results, nil
+}
+
+// toolsFromNames maps Codex's ranked tool names back to tool objects,
+// approximating a descending Score from rank since Codex reports no
+// score of its own.
+func (r *codexIndexReader) toolsFromNames(toolNames []string) []*ScoredTool {
+	toolMap := make(map[string]*tools.Tool, len(r.tools))
+	for _, tool := range r.tools {
 		toolMap[tool.Name] = tool
 	}
 
-	results := make([]*tools.Tool, 0, len(toolNames))
-	for _, name := range toolNames {
+	results := make([]*ScoredTool, 0, len(toolNames))
+	for rank, name := range toolNames {
 		if tool, ok := toolMap[name]; ok {
-			results = append(results, tool)
+			results = append(results, &ScoredTool{Tool: tool, Score: float32(len(toolNames) - rank)})
 		}
 	}
 
-	s.logger.Debug("Codex search results", "query", query, "requested", topK, "returned", len(results))
+	return results
+}
 
-	return results, nil
+// GetToolCount returns the number of tools in this snapshot.
+func (r *codexIndexReader) GetToolCount() int {
+	return len(r.tools)
 }
 
-// GetToolCount returns the number of tools indexed
-func (s *CodexSearchStore) GetToolCount() int {
-	return len(s.tools)
+// Close is a no-op: the snapshot is just slice/byte-slice headers, so
+// there is no underlying resource to release.
+func (r *codexIndexReader) Close() error {
+	return nil
 }
+
+var _ IndexReader = (*codexIndexReader)(nil)
@@ -0,0 +1,229 @@
+package llmsearch
+
+import (
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+// Embedder produces a fixed-length vector embedding for a piece of
+// text. vectorstore.GloVeEmbedder and vectorstore.SIFGloVeEmbedder both
+// satisfy it, letting GloVeSearchStore stay agnostic to which pooling
+// strategy backs its similarity search.
+type Embedder interface {
+	Generate(text string) ([]float32, error)
+}
+
+// GloVeSearchStore is a semantic SearchStore backed by an Embedder. It
+// embeds each tool's name and description once at index time and ranks
+// queries by cosine similarity against those embeddings, giving
+// HybridSearchStore a real semantic store to fuse BM25/keyword results
+// with.
+type GloVeSearchStore struct {
+	mu       sync.RWMutex
+	embedder Embedder
+	tools    []*tools.Tool
+	vectors  map[string][]float32 // keyed by tools.Tool.Name
+	logger   *slog.Logger
+}
+
+// NewGloVeSearchStore creates a semantic search store that embeds tools
+// with embedder.
+func NewGloVeSearchStore(embedder Embedder, logger *slog.Logger) *GloVeSearchStore {
+	return &GloVeSearchStore{
+		embedder: embedder,
+		tools:    make([]*tools.Tool, 0),
+		vectors:  make(map[string][]float32),
+		logger:   logger,
+	}
+}
+
+// BuildFromTools embeds every tool's name and description and replaces
+// the indexed set wholesale.
+func (s *GloVeSearchStore) BuildFromTools(allTools []*tools.Tool) error {
+	snapshot := make([]*tools.Tool, len(allTools))
+	copy(snapshot, allTools)
+
+	vectors := make(map[string][]float32, len(snapshot))
+	for _, tool := range snapshot {
+		vec, err := s.embedder.Generate(embeddingText(tool))
+		if err != nil {
+			return err
+		}
+		vectors[tool.Name] = vec
+	}
+
+	s.mu.Lock()
+	s.tools = snapshot
+	s.vectors = vectors
+	s.mu.Unlock()
+
+	s.logger.Info("Built GloVe search store", "tool_count", len(snapshot))
+	return nil
+}
+
+// Batch re-embeds adds, drops removes (matched by tool name), and
+// applies both to a fresh copy of the tools slice and vector map,
+// leaving any outstanding reader snapshot untouched.
+func (s *GloVeSearchStore) Batch(adds []*tools.Tool, removes []string) error {
+	removeSet := make(map[string]bool, len(removes))
+	for _, name := range removes {
+		removeSet[name] = true
+	}
+
+	addVectors := make(map[string][]float32, len(adds))
+	for _, tool := range adds {
+		vec, err := s.embedder.Generate(embeddingText(tool))
+		if err != nil {
+			return err
+		}
+		addVectors[tool.Name] = vec
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := make([]*tools.Tool, 0, len(s.tools)+len(adds))
+	nextVectors := make(map[string][]float32, len(s.vectors)+len(adds))
+	for _, tool := range s.tools {
+		if !removeSet[tool.Name] {
+			next = append(next, tool)
+			nextVectors[tool.Name] = s.vectors[tool.Name]
+		}
+	}
+	for _, tool := range adds {
+		next = append(next, tool)
+		nextVectors[tool.Name] = addVectors[tool.Name]
+	}
+
+	s.tools = next
+	s.vectors = nextVectors
+
+	s.logger.Info("Applied GloVe search store batch", "adds", len(adds), "removes", len(removes), "tool_count", len(next))
+	return nil
+}
+
+// embeddingText is the text embedded for a tool: name and description
+// concatenated, since GloVe/SIF pooling already ignores word order.
+func embeddingText(tool *tools.Tool) string {
+	return tool.Name + " " + tool.Description
+}
+
+// Reader returns a snapshot of the currently indexed tools and vectors.
+func (s *GloVeSearchStore) Reader() (IndexReader, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &gloveIndexReader{embedder: s.embedder, tools: s.tools, vectors: s.vectors, logger: s.logger}, nil
+}
+
+// gloveIndexReader answers searches against a fixed snapshot of tools
+// and embeddings captured by GloVeSearchStore.Reader.
+type gloveIndexReader struct {
+	embedder Embedder
+	tools    []*tools.Tool
+	vectors  map[string][]float32
+	logger   *slog.Logger
+}
+
+// Search embeds query and ranks tools by cosine similarity against
+// their stored embeddings.
+func (r *gloveIndexReader) Search(query string, topK int) ([]*ScoredTool, error) {
+	if len(r.tools) == 0 {
+		return []*ScoredTool{}, nil
+	}
+
+	queryVec, err := r.embedder.Generate(query)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := r.rank(queryVec, topK)
+
+	r.logger.Debug("GloVe search completed", "query", query, "found", len(scored))
+
+	return scored, nil
+}
+
+// SearchQuery extracts the text to embed from q (SemanticQuery.Text when
+// q itself is a SemanticQuery, otherwise q's own textual
+// representation, nested SemanticQuery nodes included) and ranks tools by
+// cosine similarity, since GloVeSearchStore has no native notion of the
+// rest of the compound query grammar (category filters, NOT, etc.).
+func (r *gloveIndexReader) SearchQuery(q query.Query, topK int) ([]*ScoredTool, error) {
+	text := q.String()
+	if sem, ok := q.(*query.SemanticQuery); ok {
+		text = sem.Text
+	}
+
+	queryVec, err := r.embedder.Generate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := r.rank(queryVec, topK)
+
+	r.logger.Debug("GloVe search query completed", "query", text, "found", len(scored))
+
+	return scored, nil
+}
+
+// rank scores every tool by cosine similarity against queryVec and
+// returns the top topK, highest similarity first.
+func (r *gloveIndexReader) rank(queryVec []float32, topK int) []*ScoredTool {
+	scored := make([]*ScoredTool, 0, len(r.tools))
+	for _, tool := range r.tools {
+		vec, ok := r.vectors[tool.Name]
+		if !ok {
+			continue
+		}
+		scored = append(scored, &ScoredTool{Tool: tool, Score: cosineSimilarity(queryVec, vec)})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	if topK < len(scored) {
+		scored = scored[:topK]
+	}
+
+	return scored
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or
+// 0 if either is the zero vector.
+func cosineSimilarity(a, b []float32) float32 {
+	var dot, normA, normB float32
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / float32(math.Sqrt(float64(normA))*math.Sqrt(float64(normB)))
+}
+
+// GetToolCount returns the number of tools in this snapshot.
+func (r *gloveIndexReader) GetToolCount() int {
+	return len(r.tools)
+}
+
+// Close is a no-op: the snapshot is just slice/map headers, so there is
+// no underlying resource to release.
+func (r *gloveIndexReader) Close() error {
+	return nil
+}
+
+var (
+	_ SearchStore = (*GloVeSearchStore)(nil)
+	_ IndexReader = (*gloveIndexReader)(nil)
+)
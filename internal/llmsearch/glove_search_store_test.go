@@ -0,0 +1,55 @@
+package llmsearch
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+// stubEmbedder returns a fixed vector for known text and the zero
+// vector otherwise, so tests can control similarity without pulling in
+// real GloVe vectors.
+type stubEmbedder struct {
+	vectors map[string][]float32
+}
+
+func (s stubEmbedder) Generate(text string) ([]float32, error) {
+	if v, ok := s.vectors[text]; ok {
+		return v, nil
+	}
+	return []float32{0, 0}, nil
+}
+
+func TestGloVeSearchStoreRanksByCosineSimilarity(t *testing.T) {
+	readTool := &tools.Tool{Name: "read_file", Category: "filesystem", Description: "Reads a file"}
+	writeTool := &tools.Tool{Name: "write_file", Category: "filesystem", Description: "Writes a file"}
+
+	embedder := stubEmbedder{vectors: map[string][]float32{
+		embeddingText(readTool):  {1, 0},
+		embeddingText(writeTool): {0, 1},
+		"read":                   {1, 0},
+	}}
+
+	store := NewGloVeSearchStore(embedder, slog.Default())
+	if err := store.BuildFromTools([]*tools.Tool{readTool, writeTool}); err != nil {
+		t.Fatalf("BuildFromTools: %v", err)
+	}
+
+	reader, err := store.Reader()
+	if err != nil {
+		t.Fatalf("Reader: %v", err)
+	}
+	defer reader.Close()
+
+	results, err := reader.Search("read", 2)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Tool.Name != "read_file" {
+		t.Errorf("expected read_file ranked first, got %s", results[0].Tool.Name)
+	}
+}
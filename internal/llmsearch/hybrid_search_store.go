@@ -0,0 +1,221 @@
+package llmsearch
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+// defaultRRFK is the Reciprocal Rank Fusion smoothing constant
+// recommended by Cormack et al., used unless overridden.
+const defaultRRFK = 60
+
+// defaultOversample is how many times topK each inner store is asked
+// for before fusion, so stores that rank a document outside the final
+// topK can still contribute to its fused score.
+const defaultOversample = 3
+
+// WeightedStore is one inner SearchStore composed by HybridSearchStore,
+// with a name for attributing RRF contributions and a weight biasing
+// fusion toward that store's recall.
+type WeightedStore struct {
+	Name   string
+	Store  SearchStore
+	Weight float32
+}
+
+// HybridSearchStore fuses the ranked results of several inner
+// SearchStore implementations (typically a BM25 lexical store and a
+// GloVe/vector semantic store) via Reciprocal Rank Fusion, addressing
+// the well-known failure of pure-embedding search on rare identifiers
+// and tool names while keeping semantic recall for paraphrased queries.
+type HybridSearchStore struct {
+	stores     []WeightedStore
+	k          float32
+	oversample int
+	logger     *slog.Logger
+}
+
+// NewHybridSearchStore composes stores with the default RRF constant
+// (k=60) and oversample factor (3x topK per inner store).
+func NewHybridSearchStore(stores []WeightedStore, logger *slog.Logger) *HybridSearchStore {
+	return &HybridSearchStore{
+		stores:     stores,
+		k:          defaultRRFK,
+		oversample: defaultOversample,
+		logger:     logger,
+	}
+}
+
+// BuildFromTools indexes allTools into every inner store.
+func (h *HybridSearchStore) BuildFromTools(allTools []*tools.Tool) error {
+	for _, ws := range h.stores {
+		if err := ws.Store.BuildFromTools(allTools); err != nil {
+			return fmt.Errorf("store %q failed to build: %w", ws.Name, err)
+		}
+	}
+	return nil
+}
+
+// Batch applies adds and removes to every inner store.
+func (h *HybridSearchStore) Batch(adds []*tools.Tool, removes []string) error {
+	for _, ws := range h.stores {
+		if err := ws.Store.Batch(adds, removes); err != nil {
+			return fmt.Errorf("store %q failed to apply batch: %w", ws.Name, err)
+		}
+	}
+	return nil
+}
+
+// Reader opens a reader on every inner store and returns a composite
+// IndexReader that fuses their results via Reciprocal Rank Fusion. If
+// opening any inner reader fails, readers already opened are closed.
+func (h *HybridSearchStore) Reader() (IndexReader, error) {
+	readers := make([]namedReader, 0, len(h.stores))
+
+	for _, ws := range h.stores {
+		r, err := ws.Store.Reader()
+		if err != nil {
+			for _, opened := range readers {
+				opened.reader.Close()
+			}
+			return nil, fmt.Errorf("store %q failed to open reader: %w", ws.Name, err)
+		}
+		readers = append(readers, namedReader{name: ws.Name, weight: ws.Weight, reader: r})
+	}
+
+	return &hybridIndexReader{readers: readers, k: h.k, oversample: h.oversample, logger: h.logger}, nil
+}
+
+// namedReader pairs an inner IndexReader snapshot with the name and
+// weight of the store it came from, for RRF fusion and attribution.
+type namedReader struct {
+	name   string
+	weight float32
+	reader IndexReader
+}
+
+// hybridIndexReader fuses searches across a fixed set of inner
+// IndexReader snapshots captured by HybridSearchStore.Reader.
+type hybridIndexReader struct {
+	readers    []namedReader
+	k          float32
+	oversample int
+	logger     *slog.Logger
+}
+
+// Search runs query against every inner reader and fuses the ranked
+// lists via Reciprocal Rank Fusion.
+func (h *hybridIndexReader) Search(query string, topK int) ([]*ScoredTool, error) {
+	perStore := make(map[string][]*ScoredTool, len(h.readers))
+
+	for _, nr := range h.readers {
+		results, err := nr.reader.Search(query, topK*h.oversample)
+		if err != nil {
+			return nil, fmt.Errorf("store %q search failed: %w", nr.name, err)
+		}
+		perStore[nr.name] = results
+	}
+
+	return h.fuse(perStore, topK), nil
+}
+
+// SearchQuery runs q against every inner reader and fuses the ranked
+// lists via Reciprocal Rank Fusion.
+func (h *hybridIndexReader) SearchQuery(q query.Query, topK int) ([]*ScoredTool, error) {
+	perStore := make(map[string][]*ScoredTool, len(h.readers))
+
+	for _, nr := range h.readers {
+		results, err := nr.reader.SearchQuery(q, topK*h.oversample)
+		if err != nil {
+			return nil, fmt.Errorf("store %q search query failed: %w", nr.name, err)
+		}
+		perStore[nr.name] = results
+	}
+
+	return h.fuse(perStore, topK), nil
+}
+
+// GetToolCount returns the tool count reported by the first configured
+// reader, since every inner store is expected to index the same tool
+// set.
+func (h *hybridIndexReader) GetToolCount() int {
+	if len(h.readers) == 0 {
+		return 0
+	}
+	return h.readers[0].reader.GetToolCount()
+}
+
+// Close releases every inner reader's snapshot.
+func (h *hybridIndexReader) Close() error {
+	var firstErr error
+	for _, nr := range h.readers {
+		if err := nr.reader.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// fuse combines each store's ranked results with weighted Reciprocal
+// Rank Fusion: RRF(d) = sum_i weight_i / (k + rank_i(d)), where a
+// document missing from store i contributes zero (the rank_i(d)=infinity
+// case). Per-store contributions are recorded on each ScoredTool for
+// debugging.
+func (h *hybridIndexReader) fuse(perStore map[string][]*ScoredTool, topK int) []*ScoredTool {
+	type fusedResult struct {
+		tool          *tools.Tool
+		score         float32
+		contributions map[string]float32
+	}
+
+	byToolName := make(map[string]*fusedResult)
+
+	for _, nr := range h.readers {
+		for rank, st := range perStore[nr.name] {
+			f, ok := byToolName[st.Tool.Name]
+			if !ok {
+				f = &fusedResult{tool: st.Tool, contributions: make(map[string]float32)}
+				byToolName[st.Tool.Name] = f
+			}
+
+			contribution := nr.weight / (h.k + float32(rank+1))
+			f.score += contribution
+			f.contributions[nr.name] = contribution
+		}
+	}
+
+	fused := make([]*fusedResult, 0, len(byToolName))
+	for _, f := range byToolName {
+		fused = append(fused, f)
+	}
+
+	sort.Slice(fused, func(i, j int) bool {
+		return fused[i].score > fused[j].score
+	})
+
+	if topK < len(fused) {
+		fused = fused[:topK]
+	}
+
+	results := make([]*ScoredTool, 0, len(fused))
+	for _, f := range fused {
+		results = append(results, &ScoredTool{
+			Tool:          f.tool,
+			Score:         f.score,
+			Contributions: f.contributions,
+		})
+	}
+
+	h.logger.Debug("Hybrid search fused results", "stores", len(h.readers), "fused_count", len(results))
+
+	return results
+}
+
+var (
+	_ SearchStore = (*HybridSearchStore)(nil)
+	_ IndexReader = (*hybridIndexReader)(nil)
+)
@@ -0,0 +1,72 @@
+package llmsearch
+
+import (
+	"log/slog"
+	"math"
+	"testing"
+
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+func TestFuseWeightedReciprocalRankFusion(t *testing.T) {
+	toolA := &tools.Tool{Name: "a"}
+	toolB := &tools.Tool{Name: "b"}
+
+	h := &hybridIndexReader{
+		readers: []namedReader{
+			{name: "lexical", weight: 1.0},
+			{name: "semantic", weight: 0.5},
+		},
+		k:      60,
+		logger: slog.Default(),
+	}
+
+	perStore := map[string][]*ScoredTool{
+		"lexical":  {{Tool: toolA, Score: 10}, {Tool: toolB, Score: 5}},
+		"semantic": {{Tool: toolB, Score: 0.9}},
+	}
+
+	fused := h.fuse(perStore, 10)
+	if len(fused) != 2 {
+		t.Fatalf("expected 2 fused results, got %d", len(fused))
+	}
+
+	byName := map[string]*ScoredTool{fused[0].Tool.Name: fused[0], fused[1].Tool.Name: fused[1]}
+
+	wantA := float32(1.0) / 61
+	wantB := float32(1.0)/62 + float32(0.5)/61
+
+	if got := byName["a"].Score; math.Abs(float64(got-wantA)) > 1e-6 {
+		t.Errorf("tool a score = %v, want %v", got, wantA)
+	}
+	if got := byName["b"].Score; math.Abs(float64(got-wantB)) > 1e-6 {
+		t.Errorf("tool b score = %v, want %v", got, wantB)
+	}
+	if byName["b"].Contributions["lexical"] == 0 || byName["b"].Contributions["semantic"] == 0 {
+		t.Errorf("expected contributions from both stores for tool b, got %v", byName["b"].Contributions)
+	}
+	if fused[0].Tool.Name != "b" {
+		t.Errorf("expected tool b (present in both stores) to rank first, got %s", fused[0].Tool.Name)
+	}
+}
+
+func TestFuseTruncatesToTopK(t *testing.T) {
+	h := &hybridIndexReader{
+		readers: []namedReader{{name: "lexical", weight: 1.0}},
+		k:       60,
+		logger:  slog.Default(),
+	}
+
+	perStore := map[string][]*ScoredTool{
+		"lexical": {
+			{Tool: &tools.Tool{Name: "a"}, Score: 3},
+			{Tool: &tools.Tool{Name: "b"}, Score: 2},
+			{Tool: &tools.Tool{Name: "c"}, Score: 1},
+		},
+	}
+
+	fused := h.fuse(perStore, 2)
+	if len(fused) != 2 {
+		t.Fatalf("expected fuse to truncate to topK=2, got %d", len(fused))
+	}
+}
@@ -3,13 +3,19 @@ package llmsearch
 import (
 	"log/slog"
 	"strings"
+	"sync"
 
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
 	"github.com/radutopala/onemcp/internal/tools"
 )
 
-// MockSearchStore is a simple in-memory search store for testing
-// It does keyword matching without calling external LLMs
+// MockSearchStore is a simple in-memory search store for testing.
+// It does keyword matching without calling external LLMs. Writes
+// replace the tools slice wholesale rather than mutating it in place,
+// so a snapshot handed out by Reader stays consistent even if a Batch
+// runs concurrently.
 type MockSearchStore struct {
+	mu     sync.RWMutex
 	tools  []*tools.Tool
 	logger *slog.Logger
 }
@@ -24,73 +30,263 @@ func NewMockSearchStore(logger *slog.Logger) *MockSearchStore {
 
 // BuildFromTools stores the tools for searching
 func (s *MockSearchStore) BuildFromTools(allTools []*tools.Tool) error {
-	s.tools = allTools
+	snapshot := make([]*tools.Tool, len(allTools))
+	copy(snapshot, allTools)
+
+	s.mu.Lock()
+	s.tools = snapshot
+	s.mu.Unlock()
+
 	s.logger.Info("Built mock search store", "tool_count", len(allTools))
 	return nil
 }
 
-// Search performs simple keyword matching for testing
-func (s *MockSearchStore) Search(query string, topK int) ([]*tools.Tool, error) {
-	if len(s.tools) == 0 {
-		return []*tools.Tool{}, nil
+// Batch applies adds and removes (matched by tool name) to a fresh copy
+// of the tools slice, leaving any outstanding reader snapshot untouched.
+func (s *MockSearchStore) Batch(adds []*tools.Tool, removes []string) error {
+	removeSet := make(map[string]bool, len(removes))
+	for _, name := range removes {
+		removeSet[name] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := make([]*tools.Tool, 0, len(s.tools)+len(adds))
+	for _, tool := range s.tools {
+		if !removeSet[tool.Name] {
+			next = append(next, tool)
+		}
+	}
+	next = append(next, adds...)
+
+	s.tools = next
+
+	s.logger.Info("Applied mock search store batch", "adds", len(adds), "removes", len(removes), "tool_count", len(next))
+	return nil
+}
+
+// Reader returns a snapshot of the currently indexed tools.
+func (s *MockSearchStore) Reader() (IndexReader, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &mockIndexReader{tools: s.tools, logger: s.logger}, nil
+}
+
+// mockIndexReader answers searches against a fixed snapshot of tools
+// captured by MockSearchStore.Reader.
+type mockIndexReader struct {
+	tools  []*tools.Tool
+	logger *slog.Logger
+}
+
+// Search performs simple keyword matching for testing, recording the
+// character offsets of each matched query word per field so callers can
+// see why a tool was chosen.
+func (r *mockIndexReader) Search(query string, topK int) ([]*ScoredTool, error) {
+	if len(r.tools) == 0 {
+		return []*ScoredTool{}, nil
 	}
 
 	// Simple keyword matching - check if query words appear in tool name or description
 	queryLower := strings.ToLower(query)
 	queryWords := strings.Fields(queryLower)
 
-	type scoredTool struct {
-		tool  *tools.Tool
-		score int
-	}
+	scored := make([]*ScoredTool, 0)
 
-	scored := make([]scoredTool, 0)
-
-	for _, tool := range s.tools {
-		score := 0
+	for _, tool := range r.tools {
+		score := float32(0)
 		nameLower := strings.ToLower(tool.Name)
 		descLower := strings.ToLower(tool.Description)
 		categoryLower := strings.ToLower(tool.Category)
 
+		matches := make(map[string][]Match)
+
 		// Score based on keyword matches
 		for _, word := range queryWords {
-			if strings.Contains(nameLower, word) {
+			if m, ok := findMatch(tool.Name, nameLower, word); ok {
 				score += 3 // Name match is worth more
+				matches["name"] = append(matches["name"], m)
 			}
-			if strings.Contains(descLower, word) {
+			if m, ok := findMatch(tool.Description, descLower, word); ok {
 				score += 2
+				matches["description"] = append(matches["description"], m)
 			}
-			if strings.Contains(categoryLower, word) {
+			if m, ok := findMatch(tool.Category, categoryLower, word); ok {
 				score += 1
+				matches["category"] = append(matches["category"], m)
 			}
 		}
 
 		if score > 0 || query == "" {
-			scored = append(scored, scoredTool{tool: tool, score: score})
+			scored = append(scored, &ScoredTool{Tool: tool, Score: score, Matches: matches})
 		}
 	}
 
 	// Sort by score (simple bubble sort for small test data)
 	for i := 0; i < len(scored); i++ {
 		for j := i + 1; j < len(scored); j++ {
-			if scored[j].score > scored[i].score {
+			if scored[j].Score > scored[i].Score {
 				scored[i], scored[j] = scored[j], scored[i]
 			}
 		}
 	}
 
 	// Return top K results
-	results := make([]*tools.Tool, 0, topK)
+	results := make([]*ScoredTool, 0, topK)
 	for i := 0; i < len(scored) && i < topK; i++ {
-		results = append(results, scored[i].tool)
+		results = append(results, scored[i])
 	}
 
-	s.logger.Debug("Mock search completed", "query", query, "found", len(results))
+	r.logger.Debug("Mock search completed", "query", query, "found", len(results))
 
 	return results, nil
 }
 
-// GetToolCount returns the number of tools indexed
-func (s *MockSearchStore) GetToolCount() int {
-	return len(s.tools)
+// findMatch locates word inside value (case-insensitively via
+// valueLower) and reports its offset and whether it covers the whole
+// field, the basis for MatchLevel.
+func findMatch(value, valueLower, word string) (Match, bool) {
+	idx := strings.Index(valueLower, word)
+	if idx < 0 {
+		return Match{}, false
+	}
+
+	level := MatchLevelPartial
+	if len(word) == len(valueLower) {
+		level = MatchLevelFull
+	}
+
+	return Match{
+		Value:      value,
+		MatchLevel: level,
+		Start:      idx,
+		Length:     len(word),
+	}, true
+}
+
+// GetToolCount returns the number of tools in this snapshot.
+func (r *mockIndexReader) GetToolCount() int {
+	return len(r.tools)
+}
+
+// Close is a no-op: the snapshot is just a slice header, so there is no
+// underlying resource to release.
+func (r *mockIndexReader) Close() error {
+	return nil
+}
+
+// SearchQuery evaluates a compound query tree against each indexed tool
+// and returns the matches ordered by score, highest first.
+func (r *mockIndexReader) SearchQuery(q query.Query, topK int) ([]*ScoredTool, error) {
+	scored := make([]*ScoredTool, 0, len(r.tools))
+
+	for _, tool := range r.tools {
+		matched, score := evalQuery(tool, q)
+		if matched {
+			scored = append(scored, &ScoredTool{Tool: tool, Score: score})
+		}
+	}
+
+	// Sort by score (simple bubble sort for small test data, matching Search above)
+	for i := 0; i < len(scored); i++ {
+		for j := i + 1; j < len(scored); j++ {
+			if scored[j].Score > scored[i].Score {
+				scored[i], scored[j] = scored[j], scored[i]
+			}
+		}
+	}
+
+	if topK < len(scored) {
+		scored = scored[:topK]
+	}
+
+	r.logger.Debug("Mock search query completed", "query", q.String(), "found", len(scored))
+
+	return scored, nil
+}
+
+// evalQuery recursively matches a single tool against a query node,
+// returning whether it matched and the score it contributed.
+func evalQuery(tool *tools.Tool, q query.Query) (bool, float32) {
+	nameLower := strings.ToLower(tool.Name)
+	descLower := strings.ToLower(tool.Description)
+	categoryLower := strings.ToLower(tool.Category)
+
+	switch n := q.(type) {
+	case *query.TermQuery:
+		term := strings.ToLower(n.Term)
+		score := float32(0)
+		if strings.Contains(nameLower, term) {
+			score += 3
+		}
+		if strings.Contains(descLower, term) {
+			score += 2
+		}
+		if strings.Contains(categoryLower, term) {
+			score += 1
+		}
+		return score > 0, score
+
+	case *query.PhraseQuery:
+		phrase := strings.ToLower(n.Phrase)
+		if strings.Contains(descLower, phrase) || strings.Contains(nameLower, phrase) {
+			return true, 2
+		}
+		return false, 0
+
+	case *query.CategoryQuery:
+		if categoryLower == strings.ToLower(n.Category) {
+			return true, 1
+		}
+		return false, 0
+
+	case *query.AndQuery:
+		var total float32
+		for _, clause := range n.Clauses {
+			matched, score := evalQuery(tool, clause)
+			if !matched {
+				return false, 0
+			}
+			total += score
+		}
+		return true, total
+
+	case *query.OrQuery:
+		var best float32
+		matchedAny := false
+		for _, clause := range n.Clauses {
+			matched, score := evalQuery(tool, clause)
+			if matched {
+				matchedAny = true
+				if score > best {
+					best = score
+				}
+			}
+		}
+		return matchedAny, best
+
+	case *query.NotQuery:
+		matched, _ := evalQuery(tool, n.Clause)
+		return !matched, 0
+
+	case *query.MinScoreQuery:
+		matched, score := evalQuery(tool, n.Inner)
+		if matched && score >= n.MinScore {
+			return true, score
+		}
+		return false, 0
+
+	case *query.SemanticQuery:
+		// MockSearchStore has no embeddings to compare against, so fall
+		// back to lexical matching on the semantic query's text.
+		matched, score := evalQuery(tool, &query.TermQuery{Term: n.Text})
+		return matched, score * n.Weight
+
+	default:
+		return false, 0
+	}
 }
+
+var _ IndexReader = (*mockIndexReader)(nil)
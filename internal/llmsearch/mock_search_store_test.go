@@ -0,0 +1,55 @@
+package llmsearch
+
+import (
+	"testing"
+
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+func TestEvalQueryAndOrNot(t *testing.T) {
+	tool := &tools.Tool{Name: "read_file", Category: "filesystem", Description: "Reads a file from disk"}
+
+	q := &query.AndQuery{Clauses: []query.Query{
+		&query.CategoryQuery{Category: "filesystem"},
+		&query.OrQuery{Clauses: []query.Query{
+			&query.TermQuery{Term: "read"},
+			&query.TermQuery{Term: "write"},
+		}},
+		&query.NotQuery{Clause: &query.TermQuery{Term: "deprecated"}},
+	}}
+
+	matched, score := evalQuery(tool, q)
+	if !matched {
+		t.Fatalf("expected match")
+	}
+	if score <= 0 {
+		t.Fatalf("expected positive score, got %v", score)
+	}
+}
+
+func TestEvalQueryMinScore(t *testing.T) {
+	tool := &tools.Tool{Name: "read_file", Category: "filesystem", Description: "Reads a file"}
+
+	low := &query.MinScoreQuery{Inner: &query.TermQuery{Term: "read"}, MinScore: 100}
+	if matched, _ := evalQuery(tool, low); matched {
+		t.Errorf("expected no match above an unreachable min score")
+	}
+
+	high := &query.MinScoreQuery{Inner: &query.TermQuery{Term: "read"}, MinScore: 1}
+	if matched, _ := evalQuery(tool, high); !matched {
+		t.Errorf("expected match at a low min score")
+	}
+}
+
+func TestEvalQuerySemanticFallsBackToTermMatch(t *testing.T) {
+	tool := &tools.Tool{Name: "read_file", Category: "filesystem", Description: "Reads a file from disk"}
+
+	matched, score := evalQuery(tool, &query.SemanticQuery{Text: "read", Weight: 0.5})
+	if !matched {
+		t.Fatalf("expected match")
+	}
+	if score <= 0 {
+		t.Errorf("expected positive weighted score, got %v", score)
+	}
+}
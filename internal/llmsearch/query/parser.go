@@ -0,0 +1,187 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse compiles a small text query syntax into a Query tree, e.g.
+// `category:filesystem AND (read OR write) NOT deprecated`. Bare terms
+// are joined with an implicit AND when no operator separates them.
+//
+// Grammar:
+//
+//	expr    := and ( "OR" and )*
+//	and     := not ( [ "AND" ] not )*
+//	not     := "NOT" not | primary
+//	primary := "(" expr ")" | "category:" IDENT | STRING | IDENT
+func Parse(input string) (Query, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &parser{tokens: tokens}
+
+	q, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q at position %d", p.tokens[p.pos], p.pos)
+	}
+
+	return q, nil
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *parser) parseExpr() (Query, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []Query{left}
+	for strings.EqualFold(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, right)
+	}
+
+	if len(clauses) == 1 {
+		return clauses[0], nil
+	}
+	return &OrQuery{Clauses: clauses}, nil
+}
+
+func (p *parser) parseAnd() (Query, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []Query{left}
+	for {
+		tok := p.peek()
+		if tok == "" || strings.EqualFold(tok, "OR") || tok == ")" {
+			break
+		}
+		if strings.EqualFold(tok, "AND") {
+			p.next()
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, right)
+	}
+
+	if len(clauses) == 1 {
+		return clauses[0], nil
+	}
+	return &AndQuery{Clauses: clauses}, nil
+}
+
+func (p *parser) parseNot() (Query, error) {
+	if strings.EqualFold(p.peek(), "NOT") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotQuery{Clause: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Query, error) {
+	tok := p.next()
+
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of query")
+	case tok == "(":
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("expected closing parenthesis")
+		}
+		return inner, nil
+	case strings.HasPrefix(tok, "category:"):
+		return &CategoryQuery{Category: strings.TrimPrefix(tok, "category:")}, nil
+	case strings.HasPrefix(tok, `"`) && strings.HasSuffix(tok, `"`) && len(tok) >= 2:
+		return &PhraseQuery{Phrase: tok[1 : len(tok)-1]}, nil
+	default:
+		return &TermQuery{Term: tok}, nil
+	}
+}
+
+// tokenize splits input into parser tokens, treating parentheses as
+// standalone tokens and keeping quoted phrases and `category:value`
+// pairs intact as single tokens.
+func tokenize(input string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			current.WriteRune(r)
+			if inQuotes {
+				flush()
+			}
+			inQuotes = !inQuotes
+		case inQuotes:
+			current.WriteRune(r)
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quoted phrase in query: %q", input)
+	}
+
+	flush()
+
+	return tokens, nil
+}
@@ -0,0 +1,115 @@
+package query
+
+import "testing"
+
+func TestParseTermQuery(t *testing.T) {
+	q, err := Parse("filesystem")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	term, ok := q.(*TermQuery)
+	if !ok {
+		t.Fatalf("expected *TermQuery, got %T", q)
+	}
+	if term.Term != "filesystem" {
+		t.Errorf("got term %q, want %q", term.Term, "filesystem")
+	}
+}
+
+func TestParseCategoryQuery(t *testing.T) {
+	q, err := Parse("category:filesystem")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cat, ok := q.(*CategoryQuery)
+	if !ok {
+		t.Fatalf("expected *CategoryQuery, got %T", q)
+	}
+	if cat.Category != "filesystem" {
+		t.Errorf("got category %q, want %q", cat.Category, "filesystem")
+	}
+}
+
+func TestParsePhraseQuery(t *testing.T) {
+	q, err := Parse(`"exact phrase"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	phrase, ok := q.(*PhraseQuery)
+	if !ok {
+		t.Fatalf("expected *PhraseQuery, got %T", q)
+	}
+	if phrase.Phrase != "exact phrase" {
+		t.Errorf("got phrase %q, want %q", phrase.Phrase, "exact phrase")
+	}
+}
+
+func TestParseImplicitAnd(t *testing.T) {
+	q, err := Parse("read write")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	and, ok := q.(*AndQuery)
+	if !ok {
+		t.Fatalf("expected *AndQuery, got %T", q)
+	}
+	if len(and.Clauses) != 2 {
+		t.Fatalf("expected 2 clauses, got %d", len(and.Clauses))
+	}
+}
+
+// TestParseCompoundQuery exercises the example from request chunk0-3:
+// explicit AND, a parenthesized OR, and a trailing implicit-AND NOT.
+func TestParseCompoundQuery(t *testing.T) {
+	q, err := Parse("category:filesystem AND (read OR write) NOT deprecated")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	and, ok := q.(*AndQuery)
+	if !ok {
+		t.Fatalf("expected top-level *AndQuery, got %T", q)
+	}
+	if len(and.Clauses) != 3 {
+		t.Fatalf("expected 3 clauses, got %d", len(and.Clauses))
+	}
+
+	if _, ok := and.Clauses[0].(*CategoryQuery); !ok {
+		t.Errorf("clause 0: expected *CategoryQuery, got %T", and.Clauses[0])
+	}
+
+	or, ok := and.Clauses[1].(*OrQuery)
+	if !ok {
+		t.Fatalf("clause 1: expected *OrQuery, got %T", and.Clauses[1])
+	}
+	if len(or.Clauses) != 2 {
+		t.Fatalf("expected 2 OR clauses, got %d", len(or.Clauses))
+	}
+
+	not, ok := and.Clauses[2].(*NotQuery)
+	if !ok {
+		t.Fatalf("clause 2: expected *NotQuery, got %T", and.Clauses[2])
+	}
+	if _, ok := not.Clause.(*TermQuery); !ok {
+		t.Errorf("NOT clause: expected *TermQuery, got %T", not.Clause)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"(unterminated",
+		`"unterminated`,
+		"term )",
+	}
+
+	for _, c := range cases {
+		if _, err := Parse(c); err == nil {
+			t.Errorf("Parse(%q): expected error, got nil", c)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package query implements a small compound query tree for SearchStore,
+// inspired by Zoekt's query package, so callers can express precise
+// tool-discovery filters instead of only free-text search strings.
+package query
+
+import "fmt"
+
+// Query is a node in a compound search query tree. Implementations are
+// lowered differently by each SearchStore: MockSearchStore evaluates
+// them directly, BleveSearchStore maps them onto Bleve's query types,
+// and CodexSearchStore renders them into a prompt via String.
+type Query interface {
+	fmt.Stringer
+}
+
+// TermQuery matches a single free-text term against a tool's searchable
+// fields (Name, Category, Description, flattened schema).
+type TermQuery struct {
+	Term string
+}
+
+func (q *TermQuery) String() string { return q.Term }
+
+// PhraseQuery matches an exact, ordered sequence of words.
+type PhraseQuery struct {
+	Phrase string
+}
+
+func (q *PhraseQuery) String() string { return fmt.Sprintf("%q", q.Phrase) }
+
+// CategoryQuery restricts results to tools in the given category.
+type CategoryQuery struct {
+	Category string
+}
+
+func (q *CategoryQuery) String() string { return fmt.Sprintf("category:%s", q.Category) }
+
+// AndQuery matches tools satisfying every clause.
+type AndQuery struct {
+	Clauses []Query
+}
+
+func (q *AndQuery) String() string { return joinClauses(q.Clauses, "AND") }
+
+// OrQuery matches tools satisfying at least one clause.
+type OrQuery struct {
+	Clauses []Query
+}
+
+func (q *OrQuery) String() string { return joinClauses(q.Clauses, "OR") }
+
+// NotQuery matches tools that do not satisfy Clause.
+type NotQuery struct {
+	Clause Query
+}
+
+func (q *NotQuery) String() string { return fmt.Sprintf("NOT %s", q.Clause) }
+
+// MinScoreQuery filters out results from Inner whose score falls below
+// MinScore.
+type MinScoreQuery struct {
+	Inner    Query
+	MinScore float32
+}
+
+func (q *MinScoreQuery) String() string {
+	return fmt.Sprintf("%s (min_score=%.2f)", q.Inner, q.MinScore)
+}
+
+// SemanticQuery asks for nearest-neighbor/semantic matches to Text,
+// weighted by Weight when combined with lexical clauses (e.g. inside a
+// HybridSearchStore).
+type SemanticQuery struct {
+	Text   string
+	Weight float32
+}
+
+func (q *SemanticQuery) String() string {
+	return fmt.Sprintf("semantic(%q, weight=%.2f)", q.Text, q.Weight)
+}
+
+// joinClauses renders a list of clauses joined by op, parenthesizing
+// multi-clause groups so nested precedence round-trips through String.
+func joinClauses(clauses []Query, op string) string {
+	if len(clauses) == 0 {
+		return ""
+	}
+
+	s := clauses[0].String()
+	for _, c := range clauses[1:] {
+		s = fmt.Sprintf("%s %s %s", s, op, c)
+	}
+
+	if len(clauses) > 1 {
+		return fmt.Sprintf("(%s)", s)
+	}
+
+	return s
+}
@@ -0,0 +1,36 @@
+package llmsearch
+
+import "github.com/radutopala/onemcp/internal/tools"
+
+// MatchLevel describes how strongly a field contributed to a search
+// match, modeled on MeiliSearch's match metadata so downstream MCP
+// clients can explain why a tool was chosen.
+type MatchLevel string
+
+const (
+	MatchLevelNone    MatchLevel = "none"
+	MatchLevelPartial MatchLevel = "partial"
+	MatchLevelFull    MatchLevel = "full"
+)
+
+// Match describes one matched substring within a field value.
+type Match struct {
+	Value      string
+	MatchLevel MatchLevel
+	Start      int
+	Length     int
+}
+
+// ScoredTool pairs a tool with the score it received from a search or
+// compound SearchQuery, plus the per-field Matches that produced that
+// score. Matches is keyed by field name ("name", "category",
+// "description") and may be nil for stores that can't report match
+// offsets (e.g. CodexSearchStore). Contributions is populated by
+// HybridSearchStore with each inner store's weighted RRF contribution,
+// keyed by store name, and is nil for non-hybrid stores.
+type ScoredTool struct {
+	Tool          *tools.Tool
+	Score         float32
+	Matches       map[string][]Match
+	Contributions map[string]float32
+}
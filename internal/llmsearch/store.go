@@ -0,0 +1,50 @@
+package llmsearch
+
+import (
+	"github.com/radutopala/onemcp/internal/llmsearch/query"
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+// SearchStore indexes a set of tools. Following Bleve's KVReader/KVWriter
+// split, it only exposes write operations; reads go through a snapshot
+// obtained via Reader, so an in-flight BuildFromTools or Batch never
+// races a concurrent search.
+type SearchStore interface {
+	// BuildFromTools replaces the full set of indexed tools.
+	BuildFromTools(allTools []*tools.Tool) error
+
+	// Batch applies an incremental set of adds and removes (matched by
+	// tools.Tool.Name) without rebuilding the whole index.
+	Batch(adds []*tools.Tool, removes []string) error
+
+	// Reader returns a consistent snapshot of the tool set as of this
+	// call. The caller must Close it once done to release the snapshot.
+	Reader() (IndexReader, error)
+}
+
+// IndexReader answers searches against a consistent snapshot of the
+// tool set captured when it was obtained from SearchStore.Reader. It
+// must be Closed to release the snapshot.
+type IndexReader interface {
+	// Search runs a free-text query and returns up to topK matches
+	// ordered by score, highest first.
+	Search(query string, topK int) ([]*ScoredTool, error)
+
+	// SearchQuery runs a compound query.Query tree and returns up to
+	// topK matches ordered by score, highest first.
+	SearchQuery(q query.Query, topK int) ([]*ScoredTool, error)
+
+	// GetToolCount returns the number of tools in this snapshot.
+	GetToolCount() int
+
+	// Close releases the snapshot. Safe to call more than once.
+	Close() error
+}
+
+var (
+	_ SearchStore = (*MockSearchStore)(nil)
+	_ SearchStore = (*CodexSearchStore)(nil)
+	_ SearchStore = (*BleveSearchStore)(nil)
+	_ SearchStore = (*HybridSearchStore)(nil)
+	_ SearchStore = (*GloVeSearchStore)(nil)
+)
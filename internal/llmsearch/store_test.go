@@ -0,0 +1,52 @@
+package llmsearch
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/radutopala/onemcp/internal/tools"
+)
+
+// TestReaderSnapshotIsolatedFromConcurrentBatch exercises the
+// writer/reader split the SearchStore interface promises: a reader
+// obtained before a Batch must keep seeing the pre-batch tool set, and
+// a reader obtained after must see the post-batch one. MockSearchStore
+// stands in for any SearchStore implementation here, since the contract
+// is defined on the interface, not on a particular backing store.
+func TestReaderSnapshotIsolatedFromConcurrentBatch(t *testing.T) {
+	store := NewMockSearchStore(slog.Default())
+	a := &tools.Tool{Name: "a", Category: "x", Description: "tool a"}
+	if err := store.BuildFromTools([]*tools.Tool{a}); err != nil {
+		t.Fatalf("BuildFromTools: %v", err)
+	}
+
+	reader, err := store.Reader()
+	if err != nil {
+		t.Fatalf("Reader: %v", err)
+	}
+
+	b := &tools.Tool{Name: "b", Category: "x", Description: "tool b"}
+	if err := store.Batch([]*tools.Tool{b}, []string{"a"}); err != nil {
+		t.Fatalf("Batch: %v", err)
+	}
+
+	results, err := reader.Search("", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Tool.Name != "a" {
+		t.Errorf("expected the reader's snapshot to still see tool %q, got %v", "a", results)
+	}
+
+	fresh, err := store.Reader()
+	if err != nil {
+		t.Fatalf("Reader: %v", err)
+	}
+	freshResults, err := fresh.Search("", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(freshResults) != 1 || freshResults[0].Tool.Name != "b" {
+		t.Errorf("expected a fresh reader to see the post-batch tool %q, got %v", "b", freshResults)
+	}
+}
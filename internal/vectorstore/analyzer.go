@@ -0,0 +1,96 @@
+package vectorstore
+
+import (
+	"strings"
+	"unicode"
+
+	snowballen "github.com/kljensen/snowball/english"
+	snowballru "github.com/kljensen/snowball/russian"
+)
+
+// Analyzer tokenizes raw text into the normalized word forms used to
+// look up GloVe vectors. GloVeEmbedder.tokenize delegates to one so
+// callers can trade off speed, language coverage, and morphological
+// matching without touching the embedder itself.
+type Analyzer interface {
+	Tokenize(text string) []string
+}
+
+// englishStopWords is the fixed stop-word list GloVeEmbedder.tokenize
+// used before analyzers were pluggable; SimpleAnalyzer and
+// EnglishSnowballAnalyzer both filter against it.
+var englishStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true,
+	"at": true, "be": true, "by": true, "for": true, "from": true,
+	"has": true, "he": true, "in": true, "is": true, "it": true,
+	"its": true, "of": true, "on": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "will": true, "with": true,
+}
+
+// SimpleAnalyzer reproduces GloVeEmbedder's original tokenize behavior:
+// ASCII-only lowercase splitting, a fixed English stop-word list, and no
+// stemming. It's the default so existing callers see no behavior change.
+type SimpleAnalyzer struct{}
+
+func (SimpleAnalyzer) Tokenize(text string) []string {
+	text = strings.ToLower(text)
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !((r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'))
+	})
+
+	filtered := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) > 1 && !englishStopWords[word] {
+			filtered = append(filtered, word)
+		}
+	}
+
+	return filtered
+}
+
+// EnglishSnowballAnalyzer Unicode-aware splits text, lowercases it,
+// drops English stop words, then stems each remaining word with the
+// Snowball/Porter2 algorithm so morphological variants ("running" vs
+// "run") map to the same vocabulary entry.
+type EnglishSnowballAnalyzer struct{}
+
+func (EnglishSnowballAnalyzer) Tokenize(text string) []string {
+	words := unicodeWordSplit(text)
+
+	filtered := make([]string, 0, len(words))
+	for _, word := range words {
+		if len([]rune(word)) > 1 && !englishStopWords[word] {
+			filtered = append(filtered, snowballen.Stem(word, true))
+		}
+	}
+
+	return filtered
+}
+
+// RussianSnowballAnalyzer is a first-cut analyzer for Russian text,
+// following the same Unicode-aware split plus Snowball stemming
+// approach as EnglishSnowballAnalyzer. It does not yet filter Russian
+// stop words.
+type RussianSnowballAnalyzer struct{}
+
+func (RussianSnowballAnalyzer) Tokenize(text string) []string {
+	words := unicodeWordSplit(text)
+
+	filtered := make([]string, 0, len(words))
+	for _, word := range words {
+		if len([]rune(word)) > 1 {
+			filtered = append(filtered, snowballru.Stem(word, true))
+		}
+	}
+
+	return filtered
+}
+
+// unicodeWordSplit lowercases text and splits it on runs of
+// non-letter/non-digit runes, unlike SimpleAnalyzer's ASCII-only split.
+func unicodeWordSplit(text string) []string {
+	text = strings.ToLower(text)
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,48 @@
+package vectorstore
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnglishSnowballAnalyzerDropsStopWordsAndStemsInflections(t *testing.T) {
+	tokens := EnglishSnowballAnalyzer{}.Tokenize("The runners are running and runs")
+	for _, stop := range []string{"the", "are", "and"} {
+		for _, tok := range tokens {
+			if tok == stop {
+				t.Errorf("expected stop word %q to be filtered out, got tokens %v", stop, tokens)
+			}
+		}
+	}
+
+	stems := make(map[string]bool)
+	for _, word := range []string{"run", "running", "runs"} {
+		stemmed := EnglishSnowballAnalyzer{}.Tokenize(word)
+		if len(stemmed) != 1 {
+			t.Fatalf("expected one token for %q, got %v", word, stemmed)
+		}
+		stems[stemmed[0]] = true
+	}
+	if len(stems) != 1 {
+		t.Errorf("expected every inflection of \"run\" to stem to the same token, got %v", stems)
+	}
+}
+
+func TestRussianSnowballAnalyzerSplitsAndLowercasesUnicode(t *testing.T) {
+	tokens := RussianSnowballAnalyzer{}.Tokenize("Привет, мир!")
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, got %v", tokens)
+	}
+	for _, tok := range tokens {
+		if tok != strings.ToLower(tok) {
+			t.Errorf("expected tokens to be lowercased, got %q", tok)
+		}
+	}
+}
+
+func TestSimpleAnalyzerDoesNotStem(t *testing.T) {
+	tokens := SimpleAnalyzer{}.Tokenize("running runner")
+	if len(tokens) != 2 || tokens[0] == tokens[1] {
+		t.Errorf("expected SimpleAnalyzer to leave inflections untouched, got %v", tokens)
+	}
+}
@@ -15,9 +15,10 @@ import (
 
 // GloVeEmbedder implements embeddings using pre-trained GloVe vectors
 type GloVeEmbedder struct {
-	vectors map[string][]float32
-	dim     int
-	logger  *slog.Logger
+	vectors  map[string][]float32
+	dim      int
+	analyzer Analyzer
+	logger   *slog.Logger
 }
 
 // GloVe model configurations
@@ -71,12 +72,54 @@ func NewGloVeEmbedder(modelName string, cacheDir string, logger *slog.Logger) (*
 	logger.Info("GloVe embedder ready", "model", modelName, "vocabulary_size", len(vectors), "dimension", modelConfig.dim)
 
 	return &GloVeEmbedder{
-		vectors: vectors,
-		dim:     modelConfig.dim,
-		logger:  logger,
+		vectors:  vectors,
+		dim:      modelConfig.dim,
+		analyzer: SimpleAnalyzer{},
+		logger:   logger,
 	}, nil
 }
 
+// NewGloVeEmbedderWithAnalyzer creates a GloVe embedder that tokenizes
+// with analyzer instead of the default SimpleAnalyzer. The vocabulary is
+// re-keyed by running analyzer over each word, so e.g. an
+// EnglishSnowballAnalyzer collapses "running"/"runs"/"run" onto a single
+// stemmed vector and cuts out-of-vocabulary rates on natural-language
+// tool descriptions.
+func NewGloVeEmbedderWithAnalyzer(modelName string, cacheDir string, analyzer Analyzer, logger *slog.Logger) (*GloVeEmbedder, error) {
+	embedder, err := NewGloVeEmbedder(modelName, cacheDir, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	embedder.analyzer = analyzer
+	embedder.vectors = restemVectors(embedder.vectors, analyzer)
+
+	logger.Info("Applied analyzer to GloVe vocabulary", "vocabulary_size", len(embedder.vectors))
+
+	return embedder, nil
+}
+
+// restemVectors re-keys vectors by running analyzer over each raw word.
+// Several raw words can stem to the same token; since loadGloVeVectors
+// already discards file order by loading into a map, collisions keep
+// whichever raw word's vector the map iteration visits first.
+func restemVectors(vectors map[string][]float32, analyzer Analyzer) map[string][]float32 {
+	stemmed := make(map[string][]float32, len(vectors))
+
+	for word, vec := range vectors {
+		tokens := analyzer.Tokenize(word)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if _, exists := stemmed[tokens[0]]; !exists {
+			stemmed[tokens[0]] = vec
+		}
+	}
+
+	return stemmed
+}
+
 // downloadAndExtractGloVe downloads and extracts the GloVe model
 func downloadAndExtractGloVe(url, targetFile, cacheDir string, logger *slog.Logger) error {
 	// Download zip file
@@ -242,28 +285,7 @@ func (e *GloVeEmbedder) Generate(text string) ([]float32, error) {
 
 // tokenize splits text into lowercase words
 func (e *GloVeEmbedder) tokenize(text string) []string {
-	text = strings.ToLower(text)
-	words := strings.FieldsFunc(text, func(r rune) bool {
-		return !((r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'))
-	})
-
-	// Filter out very short words and stop words
-	stopWords := map[string]bool{
-		"a": true, "an": true, "and": true, "are": true, "as": true,
-		"at": true, "be": true, "by": true, "for": true, "from": true,
-		"has": true, "he": true, "in": true, "is": true, "it": true,
-		"its": true, "of": true, "on": true, "that": true, "the": true,
-		"this": true, "to": true, "was": true, "will": true, "with": true,
-	}
-
-	filtered := make([]string, 0, len(words))
-	for _, word := range words {
-		if len(word) > 1 && !stopWords[word] {
-			filtered = append(filtered, word)
-		}
-	}
-
-	return filtered
+	return e.analyzer.Tokenize(text)
 }
 
 // Dimension returns the embedding dimension
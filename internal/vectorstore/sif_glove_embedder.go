@@ -0,0 +1,285 @@
+package vectorstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// defaultSIFWeight is Arora et al.'s recommended smoothing parameter `a`
+// for the SIF weighting a / (a + p(w)).
+const defaultSIFWeight = 1e-3
+
+// sifStateFilename is where the fitted corpus frequencies and principal
+// component are persisted so later runs don't need to re-fit.
+const sifStateFilename = "sif_state.json"
+
+// sifState is the JSON-persisted output of Fit: the unigram frequency
+// table p(w) and the first principal component u of the embedded
+// corpus.
+type sifState struct {
+	WordFreq  map[string]float32 `json:"word_freq"`
+	Principal []float32          `json:"principal"`
+}
+
+// SIFGloVeEmbedder implements Arora et al.'s Smooth Inverse Frequency
+// sentence embeddings on top of GloVe word vectors. Unlike plain mean
+// pooling, SIF down-weights common words by their corpus frequency and
+// removes the dominant shared direction across embedded sentences,
+// which produces noticeably better sentence similarity rankings.
+type SIFGloVeEmbedder struct {
+	*GloVeEmbedder
+	a         float32
+	wordFreq  map[string]float32
+	principal []float32
+	cacheDir  string
+}
+
+// NewSIFGloVeEmbedder creates a SIF embedder on top of the same GloVe
+// vectors used by NewGloVeEmbedder. It loads a previously fitted
+// word-frequency table and principal component from cacheDir if one
+// exists; otherwise call Fit before Generate to compute them.
+func NewSIFGloVeEmbedder(modelName string, cacheDir string, logger *slog.Logger) (*SIFGloVeEmbedder, error) {
+	base, err := NewGloVeEmbedder(modelName, cacheDir, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SIFGloVeEmbedder{
+		GloVeEmbedder: base,
+		a:             defaultSIFWeight,
+		wordFreq:      make(map[string]float32),
+		cacheDir:      cacheDir,
+	}
+
+	if err := s.loadState(); err != nil {
+		logger.Info("No usable cached SIF state found, call Fit before Generate", "cache_dir", cacheDir, "reason", err)
+	}
+
+	return s, nil
+}
+
+// Fit computes unigram frequencies over corpus and the first principal
+// component of the resulting (unweighted-common-component-removal) SIF
+// embeddings, then persists both to the cache directory so subsequent
+// runs can skip re-fitting.
+func (s *SIFGloVeEmbedder) Fit(corpus []string) error {
+	s.wordFreq = computeWordFrequencies(corpus, s.tokenize)
+
+	embeddings := make([][]float32, 0, len(corpus))
+	for _, sentence := range corpus {
+		vec, err := s.weightedAverage(sentence)
+		if err != nil {
+			return fmt.Errorf("failed to embed corpus sentence: %w", err)
+		}
+		embeddings = append(embeddings, vec)
+	}
+
+	s.principal = firstPrincipalComponent(embeddings, s.dim)
+
+	if err := s.saveState(); err != nil {
+		s.logger.Info("Failed to persist SIF state", "error", err)
+	}
+
+	return nil
+}
+
+// Generate produces a SIF-weighted sentence embedding: a weighted
+// average of word vectors with common-word down-weighting, followed by
+// removal of the projection onto the first principal component fitted
+// over the corpus.
+func (s *SIFGloVeEmbedder) Generate(text string) ([]float32, error) {
+	embedding, err := s.weightedAverage(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.principal != nil {
+		embedding = removeProjection(embedding, s.principal)
+	}
+
+	return normalize(embedding), nil
+}
+
+// weightedAverage computes (1/|s|) * sum (a / (a + p(w))) * v_w for the
+// tokens of text, where |s| counts only the tokens that have a vector,
+// without the final normalization or component removal so Fit can
+// reuse it while building the corpus matrix.
+func (s *SIFGloVeEmbedder) weightedAverage(text string) ([]float32, error) {
+	words := s.tokenize(text)
+	if len(words) == 0 {
+		return make([]float32, s.dim), nil
+	}
+
+	embedding := make([]float32, s.dim)
+	count := 0
+
+	for _, word := range words {
+		vec, ok := s.vectors[word]
+		if !ok {
+			continue
+		}
+
+		weight := s.a / (s.a + s.wordFreq[word])
+
+		for i := 0; i < s.dim; i++ {
+			embedding[i] += weight * vec[i]
+		}
+		count++
+	}
+
+	if count > 0 {
+		for i := range embedding {
+			embedding[i] /= float32(count)
+		}
+	}
+
+	return embedding, nil
+}
+
+// computeWordFrequencies tokenizes corpus with tokenize and returns each
+// word's relative frequency p(w) across all tokens.
+func computeWordFrequencies(corpus []string, tokenize func(string) []string) map[string]float32 {
+	counts := make(map[string]int)
+	total := 0
+
+	for _, sentence := range corpus {
+		for _, word := range tokenize(sentence) {
+			counts[word]++
+			total++
+		}
+	}
+
+	freq := make(map[string]float32, len(counts))
+	if total == 0 {
+		return freq
+	}
+
+	for word, count := range counts {
+		freq[word] = float32(count) / float32(total)
+	}
+
+	return freq
+}
+
+// firstPrincipalComponent estimates the dominant direction u of rows
+// via power iteration on rows^T * rows (the rows are not mean-centered,
+// so this is the uncentered second-moment matrix), which avoids pulling in
+// a full linear-algebra dependency for a single eigenvector.
+func firstPrincipalComponent(rows [][]float32, dim int) []float32 {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	u := make([]float32, dim)
+	u[0] = 1.0
+
+	const iterations = 50
+
+	for iter := 0; iter < iterations; iter++ {
+		next := make([]float32, dim)
+
+		for _, row := range rows {
+			dot := dotProduct(row, u)
+			for i := 0; i < dim; i++ {
+				next[i] += dot * row[i]
+			}
+		}
+
+		norm := float32(0)
+		for _, v := range next {
+			norm += v * v
+		}
+		norm = float32(math.Sqrt(float64(norm)))
+
+		if norm < 1e-9 {
+			return u
+		}
+
+		for i := range next {
+			next[i] /= norm
+		}
+
+		u = next
+	}
+
+	return u
+}
+
+// removeProjection subtracts v's projection onto u from v: v - u*(u^T*v).
+func removeProjection(v []float32, u []float32) []float32 {
+	dot := dotProduct(v, u)
+
+	result := make([]float32, len(v))
+	for i := range v {
+		result[i] = v[i] - dot*u[i]
+	}
+
+	return result
+}
+
+// dotProduct returns the dot product of two equal-length vectors.
+func dotProduct(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// loadState reads a previously persisted word-frequency table and
+// principal component from the cache directory. cacheDir isn't
+// namespaced per GloVe dimension, so a cached state from a different
+// model (or a hand-edited/corrupted file) can carry a principal
+// component of the wrong length; removeProjection indexes it up to
+// s.dim, so loading a shorter one as-is would panic on the next
+// Generate call instead of failing here, and a longer one would be
+// silently truncated. A dimension mismatch is treated the same as
+// no cached state: wordFreq/principal are left unset for Fit to
+// recompute.
+func (s *SIFGloVeEmbedder) loadState() error {
+	path := filepath.Join(s.cacheDir, sifStateFilename)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var state sifState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse cached SIF state: %w", err)
+	}
+
+	if state.Principal != nil && len(state.Principal) != s.dim {
+		return fmt.Errorf("cached SIF state has principal component of length %d, want %d (cache_dir=%q may be shared across GloVe dimensions)", len(state.Principal), s.dim, s.cacheDir)
+	}
+
+	s.wordFreq = state.WordFreq
+	s.principal = state.Principal
+
+	return nil
+}
+
+// saveState persists the fitted word-frequency table and principal
+// component to the cache directory.
+func (s *SIFGloVeEmbedder) saveState() error {
+	state := sifState{
+		WordFreq:  s.wordFreq,
+		Principal: s.principal,
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SIF state: %w", err)
+	}
+
+	path := filepath.Join(s.cacheDir, sifStateFilename)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write SIF state: %w", err)
+	}
+
+	return nil
+}
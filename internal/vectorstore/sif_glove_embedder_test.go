@@ -0,0 +1,105 @@
+package vectorstore
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDotProduct(t *testing.T) {
+	a := []float32{1, 2, 3}
+	b := []float32{4, 5, 6}
+
+	got := dotProduct(a, b)
+	want := float32(1*4 + 2*5 + 3*6)
+	if got != want {
+		t.Errorf("dotProduct = %v, want %v", got, want)
+	}
+}
+
+func TestRemoveProjectionZeroesOutComponentAlongU(t *testing.T) {
+	u := []float32{1, 0}
+	v := []float32{3, 4}
+
+	result := removeProjection(v, u)
+
+	if math.Abs(float64(result[0])) > 1e-6 {
+		t.Errorf("expected the u-component to be removed, got %v", result[0])
+	}
+	if result[1] != 4 {
+		t.Errorf("expected the orthogonal component to be untouched, got %v", result[1])
+	}
+}
+
+func TestFirstPrincipalComponentFindsDominantAxis(t *testing.T) {
+	rows := [][]float32{
+		{1, 0.01},
+		{2, -0.01},
+		{3, 0.02},
+		{-1, 0},
+		{-2, 0.01},
+	}
+
+	u := firstPrincipalComponent(rows, 2)
+	if u == nil {
+		t.Fatalf("expected a non-nil principal component")
+	}
+
+	norm := math.Sqrt(float64(u[0]*u[0] + u[1]*u[1]))
+	if math.Abs(norm-1) > 1e-3 {
+		t.Errorf("expected principal component to be unit length, got norm %v", norm)
+	}
+
+	if math.Abs(float64(u[0])) < math.Abs(float64(u[1])) {
+		t.Errorf("expected the dominant axis (first coordinate) to have the larger weight, got u=%v", u)
+	}
+}
+
+func TestFirstPrincipalComponentEmptyInput(t *testing.T) {
+	if u := firstPrincipalComponent(nil, 4); u != nil {
+		t.Errorf("expected nil for empty input, got %v", u)
+	}
+}
+
+func TestLoadStateRejectsDimensionMismatch(t *testing.T) {
+	dir := t.TempDir()
+	writeSIFState(t, dir, sifState{WordFreq: map[string]float32{"a": 0.5}, Principal: []float32{1, 0, 0}})
+
+	s := &SIFGloVeEmbedder{GloVeEmbedder: &GloVeEmbedder{dim: 2, logger: slog.Default()}, cacheDir: dir}
+
+	if err := s.loadState(); err == nil {
+		t.Fatalf("expected an error for a mismatched principal component length")
+	}
+	if s.principal != nil {
+		t.Errorf("expected principal to remain unset after a failed load, got %v", s.principal)
+	}
+}
+
+func TestLoadStateAcceptsMatchingDimension(t *testing.T) {
+	dir := t.TempDir()
+	writeSIFState(t, dir, sifState{WordFreq: map[string]float32{"a": 0.5}, Principal: []float32{1, 0}})
+
+	s := &SIFGloVeEmbedder{GloVeEmbedder: &GloVeEmbedder{dim: 2, logger: slog.Default()}, cacheDir: dir}
+
+	if err := s.loadState(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.principal) != 2 {
+		t.Errorf("expected principal to be loaded, got %v", s.principal)
+	}
+}
+
+func writeSIFState(t *testing.T, dir string, state sifState) {
+	t.Helper()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, sifStateFilename), data, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}